@@ -0,0 +1,210 @@
+// Package atomicalign defines an Analyzer that flags struct fields holding
+// a 64-bit value accessed via sync/atomic whose offset is not a multiple
+// of 8 under a 4-byte-word architecture (386, arm, mips, mipsle) -- the
+// layout the Go runtime's own atomic tests guard against, since those
+// targets only guarantee 4-byte alignment for ordinary int64/uint64
+// fields, and the first 64-bit atomic word in a struct must itself be
+// 8-byte aligned.
+package atomicalign
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/fixclaim"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// thirtyTwoBitArch is the 4-byte-word, 4-byte-max-align model this checker
+// evaluates offsets under. 386, arm, mips, and mipsle all share this model,
+// so checking under any one of them covers all four.
+const thirtyTwoBitArch = "386"
+
+// atomic64Funcs names the sync/atomic functions whose first argument is
+// the address of the 64-bit value they operate on.
+var atomic64Funcs = map[string]bool{
+	"LoadInt64": true, "StoreInt64": true, "AddInt64": true,
+	"SwapInt64": true, "CompareAndSwapInt64": true,
+	"LoadUint64": true, "StoreUint64": true, "AddUint64": true,
+	"SwapUint64": true, "CompareAndSwapUint64": true,
+}
+
+const doc = `check for 64-bit atomic fields misaligned on 32-bit targets
+
+This analyzer reports struct fields holding a value accessed atomically as
+64 bits (int64/uint64 passed to sync/atomic's *Int64/*Uint64 functions, or
+an atomic.Int64/atomic.Uint64 field) whose offset is not a multiple of 8
+under a 4-byte-word architecture. On 386, arm, mips, and mipsle, the first
+word of such a value must be 8-byte aligned for the atomic instructions to
+work; ordinary int64/uint64 fields are only guaranteed 4-byte alignment on
+those targets, so their position in the struct matters. A field is treated
+as atomically accessed if its type is atomic.Int64/atomic.Uint64, or if it
+is passed by address to one of sync/atomic's 64-bit functions anywhere in
+the analyzed package.`
+
+// Analyzer reports 64-bit atomic fields misaligned on 32-bit targets.
+//
+// It is the base of this module's fix-priority chain (see internal/
+// fixclaim): a misaligned atomic is a correctness bug, not just an
+// optimization, so its fix always wins when another struct-layout
+// analyzer would also propose an edit to the same struct. Its Result is
+// a fixclaim.Set of the structs it claimed.
+var Analyzer = &analysis.Analyzer{
+	Name:       "atomicalign",
+	Doc:        doc,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer},
+	Run:        run,
+	ResultType: reflect.TypeOf(fixclaim.Set{}),
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	atomicFields := findAtomicallyUsedFields(pass, insp)
+	sizes := layout.SizesFor(thirtyTwoBitArch)
+
+	claimed := fixclaim.Set{}
+	insp.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		if st.Fields == nil || len(st.Fields.List) == 0 {
+			return
+		}
+		if fieldsrc.Skip(pass.TypesInfo, st) {
+			return
+		}
+		structType, ok := pass.TypesInfo.TypeOf(st).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		offsets := layout.Offsets(layout.FromStruct(structType, sizes))
+		for i := 0; i < structType.NumFields(); i++ {
+			field := structType.Field(i)
+			if !isAtomic64(field, atomicFields) || offsets[i]%8 == 0 {
+				continue
+			}
+			if reportMisaligned(pass, st, field, offsets[i]) {
+				claimed = claimed.Add(st.Pos())
+			}
+		}
+	})
+
+	return claimed, nil
+}
+
+// findAtomicallyUsedFields scans every call in the package for
+// atomic.LoadInt64(&x.Field)-style usage and returns the set of fields
+// passed by address to a 64-bit sync/atomic function.
+func findAtomicallyUsedFields(pass *analysis.Pass, insp *inspector.Inspector) map[*types.Var]bool {
+	fields := map[*types.Var]bool{}
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+		sel, ok := call.Fun.(*ast.SelectorExpr)
+		if !ok || len(call.Args) == 0 {
+			return
+		}
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync/atomic" || !atomic64Funcs[fn.Name()] {
+			return
+		}
+		addr, ok := call.Args[0].(*ast.UnaryExpr)
+		if !ok || addr.Op != token.AND {
+			return
+		}
+		fieldSel, ok := addr.X.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		if v, ok := pass.TypesInfo.ObjectOf(fieldSel.Sel).(*types.Var); ok && v.IsField() {
+			fields[v] = true
+		}
+	})
+
+	return fields
+}
+
+// isAtomic64 reports whether field holds a value accessed atomically as
+// 64 bits: an atomic.Int64/atomic.Uint64, or a plain int64/uint64 that
+// appears in atomicFields.
+func isAtomic64(field *types.Var, atomicFields map[*types.Var]bool) bool {
+	if named, ok := field.Type().(*types.Named); ok {
+		obj := named.Obj()
+		if obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == "sync/atomic" {
+			switch obj.Name() {
+			case "Int64", "Uint64":
+				return true
+			}
+		}
+	}
+	basic, ok := field.Type().Underlying().(*types.Basic)
+	if !ok || (basic.Kind() != types.Int64 && basic.Kind() != types.Uint64) {
+		return false
+	}
+	return atomicFields[field]
+}
+
+// reportMisaligned reports field's misalignment and, if it can locate the
+// ast.Field declaring it, suggests moving it to the top of the struct --
+// always 8-byte aligned, regardless of what follows. It reports whether it
+// attached a SuggestedFix, so the caller can record the struct as claimed.
+func reportMisaligned(pass *analysis.Pass, st *ast.StructType, field *types.Var, offset int64) bool {
+	msg := fmt.Sprintf("field %q is a 64-bit atomic at offset %d, not 8-byte aligned on 32-bit architectures (word size 4)", field.Name(), offset)
+
+	groups := fieldsrc.Groups(pass.TypesInfo, pass.TypesSizes, st)
+	groupIdx := indexOfField(pass, groups, field)
+	if groupIdx < 0 {
+		pass.Report(analysis.Diagnostic{Pos: st.Pos(), End: st.End(), Message: msg})
+		return false
+	}
+
+	content, err := pass.ReadFile(pass.Fset.Position(st.Pos()).Filename)
+	if err != nil {
+		pass.Report(analysis.Diagnostic{Pos: st.Pos(), End: st.End(), Message: msg})
+		return false
+	}
+
+	order := make([]int, 0, len(groups))
+	order = append(order, groupIdx)
+	for i := range groups {
+		if i != groupIdx {
+			order = append(order, i)
+		}
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     st.Pos(),
+		End:     st.End(),
+		Message: msg,
+		SuggestedFixes: []analysis.SuggestedFix{{
+			Message: "move the atomic field to the top of the struct",
+			TextEdits: []analysis.TextEdit{{
+				Pos:     st.Pos(),
+				End:     st.End(),
+				NewText: fieldsrc.Render(pass.Fset, content, st, groups, order),
+			}},
+		}},
+	})
+	return true
+}
+
+// indexOfField returns the index of the group declaring field, or -1 if
+// none of groups' names resolve to it.
+func indexOfField(pass *analysis.Pass, groups []fieldsrc.Group, field *types.Var) int {
+	for i, g := range groups {
+		for _, name := range g.Field.Names {
+			if pass.TypesInfo.ObjectOf(name) == field {
+				return i
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,14 @@
+package atomicalign_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/avifenesh/audit-struct/analyzer/atomicalign"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, atomicalign.Analyzer, "a")
+}
@@ -0,0 +1,31 @@
+package a
+
+import "sync/atomic"
+
+// Counter has a 64-bit field accessed atomically; under a 4-byte-word
+// architecture its offset is not a multiple of 8.
+type Counter struct { // want `field "count" is a 64-bit atomic at offset 4, not 8-byte aligned on 32-bit architectures \(word size 4\)`
+	flag  bool
+	count int64
+}
+
+func bump(c *Counter) int64 {
+	return atomic.AddInt64(&c.count, 1)
+}
+
+// AlreadyAligned starts with its atomic field, so it's always fine.
+type AlreadyAligned struct {
+	count int64
+	flag  bool
+}
+
+func bump2(c *AlreadyAligned) int64 {
+	return atomic.AddInt64(&c.count, 1)
+}
+
+// NotAtomic has an int64 field that's never passed to sync/atomic, so it
+// isn't flagged even though it shares Counter's layout.
+type NotAtomic struct {
+	flag  bool
+	count int64
+}
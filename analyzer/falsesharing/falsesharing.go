@@ -0,0 +1,281 @@
+// Package falsesharing defines an Analyzer that flags struct fields likely
+// to suffer false sharing: independently-mutated "hot" fields that land in
+// the same CPU cache line, forcing cores that touch either one to bounce
+// the whole line between caches.
+package falsesharing
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/avifenesh/audit-struct/analyzer/atomicalign"
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/fixclaim"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// cacheLineSize is the cache line width this checker partitions structs
+// into. 64 bytes covers every mainstream amd64/arm64 target.
+const cacheLineSize = 64
+
+const doc = `check for struct fields likely to cause false sharing
+
+This analyzer reports structs where two or more "hot" fields -- fields
+mutated independently by concurrent goroutines -- land in the same
+64-byte cache line. A field counts as hot if it has a sync/atomic type
+(atomic.Int64, atomic.Uint32, ...), a name containing "atomic", a
+"audit:hot" doc or line comment, or is named by the -hot flag. It
+suggests inserting padding after the first offending field to push the
+rest of the struct onto the next cache line.`
+
+var hotNames string
+
+// Analyzer reports hot struct fields sharing a cache line.
+//
+// It sits below atomicalign in this module's fix-priority chain (see
+// internal/fixclaim): if atomicalign already claimed a struct's fix, this
+// analyzer still reports the cache-line finding, just without its own
+// SuggestedFix, so -fix never sees two analyzers propose overlapping
+// edits to the same struct. Its Result is the union of atomicalign's
+// claimed set with whatever it claims itself.
+var Analyzer = &analysis.Analyzer{
+	Name:       "falsesharing",
+	Doc:        doc,
+	Flags:      flags(),
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, atomicalign.Analyzer},
+	Run:        run,
+	ResultType: reflect.TypeOf(fixclaim.Set{}),
+}
+
+func flags() flag.FlagSet {
+	var fs flag.FlagSet
+	fs.StringVar(&hotNames, "hot", "", "comma-separated field names to treat as hot in addition to the built-in heuristics")
+	return fs
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	hotSet := map[string]bool{}
+	for _, n := range strings.Split(hotNames, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			hotSet[n] = true
+		}
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	claimed := pass.ResultOf[atomicalign.Analyzer].(fixclaim.Set)
+	nodeFilter := []ast.Node{(*ast.StructType)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		if st.Fields == nil || len(st.Fields.List) < 2 {
+			return
+		}
+		if fieldsrc.Skip(pass.TypesInfo, st) {
+			return
+		}
+
+		groups := fieldsrc.Groups(pass.TypesInfo, pass.TypesSizes, st)
+		hot := make([]bool, len(groups))
+		for i, g := range groups {
+			hot[i] = isHot(pass, g.Field, hotSet)
+		}
+
+		starts, ends := groupOffsets(groups)
+		line, occupants := firstContendedLine(groups, hot, starts, ends)
+		if occupants == nil {
+			return
+		}
+
+		names := make([]string, len(occupants))
+		for i, idx := range occupants {
+			names[i] = fieldName(groups[idx].Field)
+		}
+
+		msg := fmt.Sprintf("fields %s are independently hot but share 64-byte cache line %d", strings.Join(quoteAll(names), " and "), line)
+
+		first := occupants[0]
+		pad := cacheLineSize - ends[first]%cacheLineSize
+		if pad <= 0 || pad >= cacheLineSize {
+			return
+		}
+
+		if claimed.Claims(st.Pos()) {
+			pass.Report(analysis.Diagnostic{Pos: st.Pos(), End: st.End(), Message: msg})
+			return
+		}
+
+		content, err := pass.ReadFile(pass.Fset.Position(st.Pos()).Filename)
+		if err != nil {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     st.Pos(),
+			End:     st.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "insert padding to separate the hot fields onto different cache lines",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     st.Pos(),
+					End:     st.End(),
+					NewText: renderWithPad(pass, content, st, groups, first, pad),
+				}},
+			}},
+		})
+		claimed = claimed.Add(st.Pos())
+	})
+
+	return claimed, nil
+}
+
+// groupOffsets returns, for each field group in declaration order, the byte
+// offset its first unit starts at and the byte offset just past its last
+// unit, both under the current (declaration) order.
+func groupOffsets(groups []fieldsrc.Group) (starts, ends []int64) {
+	elems := fieldsrc.Elements(groups)
+	unitOffsets := layout.Offsets(elems)
+	starts = make([]int64, len(groups))
+	ends = make([]int64, len(groups))
+	unit := 0
+	for i, e := range elems {
+		starts[i] = unitOffsets[unit]
+		ends[i] = starts[i] + e.Size*int64(e.Count)
+		unit += e.Count
+	}
+	return starts, ends
+}
+
+// firstContendedLine returns the lowest cache line index occupied by two or
+// more hot groups, and the indices of every hot group occupying it, in
+// declaration order. It returns (0, nil) if no line is contended.
+func firstContendedLine(groups []fieldsrc.Group, hot []bool, starts, ends []int64) (int64, []int) {
+	occupants := map[int64][]int{}
+	var lines []int64
+	for i := range groups {
+		if !hot[i] {
+			continue
+		}
+		for line := starts[i] / cacheLineSize; line <= (ends[i]-1)/cacheLineSize; line++ {
+			if occupants[line] == nil {
+				lines = append(lines, line)
+			}
+			occupants[line] = append(occupants[line], i)
+		}
+	}
+	var best int64 = -1
+	for _, line := range lines {
+		if len(occupants[line]) < 2 {
+			continue
+		}
+		if best == -1 || line < best {
+			best = line
+		}
+	}
+	if best == -1 {
+		return 0, nil
+	}
+	return best, occupants[best]
+}
+
+// isHot reports whether f should be treated as independently mutated:
+// a sync/atomic type, a name containing "atomic", an "audit:hot" doc or
+// line comment, or a name in hotSet.
+func isHot(pass *analysis.Pass, f *ast.Field, hotSet map[string]bool) bool {
+	if mentionsHot(f.Doc) || mentionsHot(f.Comment) {
+		return true
+	}
+	if isAtomicType(pass.TypesInfo.TypeOf(f.Type)) {
+		return true
+	}
+	if len(f.Names) == 0 {
+		return false
+	}
+	for _, n := range f.Names {
+		if hotSet[n.Name] || strings.Contains(strings.ToLower(n.Name), "atomic") {
+			return true
+		}
+	}
+	return false
+}
+
+// mentionsHot reports whether cg contains an "audit:hot" marker. It scans
+// the raw comment text rather than CommentGroup.Text, which drops
+// directive-shaped comments like "//audit:hot" (no space after "//").
+func mentionsHot(cg *ast.CommentGroup) bool {
+	if cg == nil {
+		return false
+	}
+	for _, c := range cg.List {
+		if strings.Contains(c.Text, "audit:hot") {
+			return true
+		}
+	}
+	return false
+}
+
+func isAtomicType(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == "sync/atomic"
+}
+
+func fieldName(f *ast.Field) string {
+	if len(f.Names) > 0 {
+		return f.Names[0].Name
+	}
+	return types.ExprString(f.Type)
+}
+
+// renderWithPad rebuilds st's source text with its fields in their original
+// order, splicing a "_ [pad]byte" field in right after the group at index
+// after so the fields that follow start on the next cache line. Like
+// fieldsrc.Render, it slices each field's original text (doc comment,
+// struct tag, and all) straight out of content rather than re-printing the
+// AST, to keep everything else byte-for-byte intact.
+func renderWithPad(pass *analysis.Pass, content []byte, st *ast.StructType, groups []fieldsrc.Group, after int, pad int64) []byte {
+	fset := pass.Fset
+	offset := func(p token.Pos) int { return fset.Position(p).Offset }
+
+	var buf bytes.Buffer
+	buf.Write(content[offset(st.Pos()) : offset(st.Fields.Opening)+1]) // "struct {"
+	buf.WriteByte('\n')
+	for i, g := range groups {
+		f := g.Field
+		start := f.Pos()
+		if f.Doc != nil {
+			start = f.Doc.Pos()
+		}
+		end := f.End()
+		if f.Comment != nil {
+			end = f.Comment.End()
+		}
+		buf.Write(content[offset(start):offset(end)])
+		buf.WriteByte('\n')
+		if i == after {
+			fmt.Fprintf(&buf, "_ [%d]byte\n", pad)
+		}
+	}
+	buf.Write(content[offset(st.Fields.Closing):offset(st.End())]) // "}"
+	return buf.Bytes()
+}
+
+func quoteAll(names []string) []string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return quoted
+}
@@ -0,0 +1,14 @@
+package falsesharing_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/avifenesh/audit-struct/analyzer/falsesharing"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, falsesharing.Analyzer, "a")
+}
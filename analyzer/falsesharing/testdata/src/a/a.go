@@ -0,0 +1,23 @@
+package a
+
+import "sync/atomic"
+
+// Counters is bumped by concurrent readers and writers; packing both
+// counters into the struct's first 64-byte cache line causes false
+// sharing between the goroutines that mutate them independently.
+type Counters struct { // want `fields "reads" and "writes" are independently hot but share 64-byte cache line 0`
+	reads  atomic.Int64
+	writes atomic.Int64
+}
+
+// Solo has only one hot field, so there is nothing to separate.
+type Solo struct {
+	reads atomic.Int64
+	label string
+}
+
+// Annotated has no atomic types but is marked hot by comment.
+type Annotated struct { // want `fields "a" and "b" are independently hot but share 64-byte cache line 0`
+	a int64 //audit:hot
+	b int64 //audit:hot
+}
@@ -0,0 +1,95 @@
+// Package fieldalign defines an Analyzer that flags struct declarations
+// whose field order wastes space to padding, and suggests a reordering that
+// minimizes the struct's size.
+package fieldalign
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/avifenesh/audit-struct/analyzer/pointerbytes"
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/fixclaim"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+const doc = `check for struct fields that could be reordered to reduce size
+
+This analyzer reports struct types whose fields, in declaration order, lay
+out larger than necessary due to compiler-inserted padding. It suggests a
+fix that reorders the fields by descending alignment and then descending
+size (remaining ties broken by declaration order, with zero-sized fields
+kept last) to produce the smallest possible layout.`
+
+// Analyzer reports structs that can be made smaller by reordering fields.
+//
+// It sits at the bottom of this module's fix-priority chain (see
+// internal/fixclaim): minimizing plain size is the most generic of this
+// module's concerns, so wherever a higher-priority analyzer (atomicalign,
+// falsesharing, pointerbytes) already claimed a struct's fix, this
+// analyzer still reports the size finding, just without its own
+// SuggestedFix -- otherwise -fix would see two analyzers propose
+// overlapping edits to the same struct and refuse to apply either.
+var Analyzer = &analysis.Analyzer{
+	Name:     "fieldalign",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer, pointerbytes.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	claimed := pass.ResultOf[pointerbytes.Analyzer].(fixclaim.Set)
+	nodeFilter := []ast.Node{(*ast.StructType)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		if st.Fields == nil || len(st.Fields.List) < 2 {
+			return
+		}
+		if fieldsrc.Skip(pass.TypesInfo, st) {
+			return
+		}
+
+		groups := fieldsrc.Groups(pass.TypesInfo, pass.TypesSizes, st)
+		elems := fieldsrc.Elements(groups)
+
+		currentSize := layout.Simulate(elems)
+		order, optimalSize := layout.OptimalSize(elems)
+		if optimalSize >= currentSize {
+			return
+		}
+
+		msg := fmt.Sprintf("struct of size %d could be %d after reordering fields", currentSize, optimalSize)
+
+		if claimed.Claims(st.Pos()) {
+			pass.Report(analysis.Diagnostic{Pos: st.Pos(), End: st.End(), Message: msg})
+			return
+		}
+
+		content, err := pass.ReadFile(pass.Fset.Position(st.Pos()).Filename)
+		if err != nil {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     st.Pos(),
+			End:     st.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "reorder fields to minimize size",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     st.Pos(),
+					End:     st.End(),
+					NewText: fieldsrc.Render(pass.Fset, content, st, groups, order),
+				}},
+			}},
+		})
+	})
+
+	return nil, nil
+}
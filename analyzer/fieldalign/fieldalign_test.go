@@ -0,0 +1,14 @@
+package fieldalign_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/avifenesh/audit-struct/analyzer/fieldalign"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, fieldalign.Analyzer, "a")
+}
@@ -0,0 +1,30 @@
+package a
+
+// PoorlyAligned has internal padding due to field ordering. Its fix ties
+// WellAligned's 16-byte size, but isn't byte-for-byte identical to it: the
+// tiebreak for fields of equal alignment and size is declaration order, and
+// flag (declared before small here) sorts ahead of it, unlike in
+// WellAligned's hand-written order.
+type PoorlyAligned struct { // want "struct of size 24 could be 16 after reordering fields"
+	flag     bool
+	bigValue uint64
+	small    uint8
+	medium   uint32
+}
+
+// WellAligned is already optimally ordered.
+type WellAligned struct {
+	bigValue uint64
+	medium   uint32
+	small    uint8
+	flag     bool
+}
+
+// Flags exercises a multi-name field declaration that must be reordered as
+// a single unit, keeping a, b, c and d adjacent and in their original
+// relative order.
+type Flags struct { // want "struct of size 24 could be 16 after reordering fields"
+	a, b, c, d bool
+	big        uint64
+	value      uint32
+}
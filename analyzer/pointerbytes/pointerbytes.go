@@ -0,0 +1,157 @@
+// Package pointerbytes defines an Analyzer that flags struct declarations
+// whose field order makes the garbage collector scan more of the struct
+// than necessary, and suggests grouping pointer-bearing fields first.
+package pointerbytes
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"sort"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/avifenesh/audit-struct/analyzer/falsesharing"
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/fixclaim"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+const doc = `check for struct fields that could be reordered to reduce GC scan size
+
+This analyzer reports struct types where fields that need no pointer scan
+(ints, floats, bools, arrays thereof, ...) sit ahead of pointer-bearing
+fields (pointers, slices, maps, channels, funcs, interfaces, strings).
+Bytes after the last pointer-bearing field are never scanned by the
+garbage collector, so grouping pointer fields first minimizes the number
+of bytes it must scan on every collection, independent of the struct's
+total size.`
+
+// Analyzer reports structs whose pointer-scan prefix can be shrunk by
+// reordering fields.
+//
+// It sits below falsesharing in this module's fix-priority chain (see
+// internal/fixclaim): if a higher-priority analyzer already claimed a
+// struct's fix, this analyzer still reports the GC-scan finding, just
+// without its own SuggestedFix, so -fix never sees two analyzers propose
+// overlapping edits to the same struct. Its Result is the union of
+// falsesharing's claimed set with whatever it claims itself.
+var Analyzer = &analysis.Analyzer{
+	Name:       "pointerbytes",
+	Doc:        doc,
+	Requires:   []*analysis.Analyzer{inspect.Analyzer, falsesharing.Analyzer},
+	Run:        run,
+	ResultType: reflect.TypeOf(fixclaim.Set{}),
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	claimed := pass.ResultOf[falsesharing.Analyzer].(fixclaim.Set)
+	nodeFilter := []ast.Node{(*ast.StructType)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		if st.Fields == nil || len(st.Fields.List) < 2 {
+			return
+		}
+		if fieldsrc.Skip(pass.TypesInfo, st) {
+			return
+		}
+
+		structType, ok := pass.TypesInfo.TypeOf(st).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		groups := fieldsrc.Groups(pass.TypesInfo, pass.TypesSizes, st)
+		currentBytes := layout.PointerBytes(structType, pass.TypesSizes)
+
+		order := optimalOrder(groups)
+		optimalBytes := layout.PointerBytes(reordered(structType, groups, order), pass.TypesSizes)
+		if optimalBytes >= currentBytes {
+			return
+		}
+
+		msg := fmt.Sprintf("garbage collector scans %d bytes of this struct, could be %d after reordering fields", currentBytes, optimalBytes)
+
+		if claimed.Claims(st.Pos()) {
+			pass.Report(analysis.Diagnostic{Pos: st.Pos(), End: st.End(), Message: msg})
+			return
+		}
+
+		content, err := pass.ReadFile(pass.Fset.Position(st.Pos()).Filename)
+		if err != nil {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     st.Pos(),
+			End:     st.End(),
+			Message: msg,
+			SuggestedFixes: []analysis.SuggestedFix{{
+				Message: "reorder fields to shrink the pointer-scan prefix",
+				TextEdits: []analysis.TextEdit{{
+					Pos:     st.Pos(),
+					End:     st.End(),
+					NewText: fieldsrc.Render(pass.Fset, content, st, groups, order),
+				}},
+			}},
+		})
+		claimed = claimed.Add(st.Pos())
+	})
+
+	return claimed, nil
+}
+
+// optimalOrder places pointer-bearing field groups ahead of scalar-only
+// ones, preserving each group's relative declaration order within its
+// bucket (the sort is stable).
+func optimalOrder(groups []fieldsrc.Group) []int {
+	order := make([]int, len(groups))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return groups[order[a]].HasPointer && !groups[order[b]].HasPointer
+	})
+	return order
+}
+
+// reordered builds a *types.Struct with s's fields permuted by order, so
+// layout.PointerBytes can be evaluated against the proposed order without
+// touching the AST. order indexes groups, one entry per field declaration,
+// but s has one *types.Var per declared name; a multi-name group like
+// "a, b *int" must expand to both of s's Vars for that declaration, in
+// their original relative order, or they'd be silently dropped from the
+// synthetic struct.
+func reordered(s *types.Struct, groups []fieldsrc.Group, order []int) *types.Struct {
+	starts := make([]int, len(groups))
+	next := 0
+	for i, g := range groups {
+		starts[i] = next
+		next += namesInGroup(g)
+	}
+
+	var vars []*types.Var
+	var tags []string
+	for _, gi := range order {
+		for j := 0; j < namesInGroup(groups[gi]); j++ {
+			fi := starts[gi] + j
+			vars = append(vars, s.Field(fi))
+			tags = append(tags, s.Tag(fi))
+		}
+	}
+	return types.NewStruct(vars, tags)
+}
+
+// namesInGroup returns the number of s.Field entries g's declaration
+// expands to: one per declared name, or 1 for an embedded field.
+func namesInGroup(g fieldsrc.Group) int {
+	if n := len(g.Field.Names); n > 0 {
+		return n
+	}
+	return 1
+}
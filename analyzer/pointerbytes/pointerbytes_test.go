@@ -0,0 +1,14 @@
+package pointerbytes_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/avifenesh/audit-struct/analyzer/pointerbytes"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, pointerbytes.Analyzer, "a")
+}
@@ -0,0 +1,22 @@
+package a
+
+// WithSlice has a pointer-bearing field (items) after a scalar (count),
+// so the garbage collector must scan further into the struct than needed.
+type WithSlice struct { // want `garbage collector scans 16 bytes of this struct, could be 8 after reordering fields`
+	count int
+	items []int
+}
+
+// AlreadyGrouped has its pointer field first, so nothing to improve.
+type AlreadyGrouped struct {
+	items []int
+	count int
+}
+
+// MultiName groups two names per declaration, so the fix must expand each
+// group into its individual fields rather than permuting declarations
+// whole -- otherwise a and b would be dropped from the reordered struct.
+type MultiName struct { // want `garbage collector scans 32 bytes of this struct, could be 16 after reordering fields`
+	c, d int
+	a, b *int
+}
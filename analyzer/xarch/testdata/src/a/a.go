@@ -0,0 +1,19 @@
+package a
+
+// Mixed has a fixed-size array competing with a pointer for the widest
+// alignment. On 64-bit targets the pointer (8-byte aligned) sorts first;
+// on 32-bit targets it ties the array's 4-byte alignment and loses the
+// size tiebreak, so the optimal order flips.
+type Mixed struct { // want `layout varies by target \(size/align/ptr-bytes\): 386=16/4/12, arm=16/4/12, amd64=24/8/16, arm64=24/8/16, wasm=24/8/16, amd64p32=16/4/12; optimal field order differs across these architectures`
+	arr  [2]int32
+	ptr  *int
+	flag bool
+}
+
+// AllInts has no pointers, so its optimal order is architecture
+// independent.
+type AllInts struct {
+	small uint8
+	big   uint64
+	mid   uint32
+}
@@ -0,0 +1,82 @@
+// Package xarch defines an Analyzer that reports struct layouts whose
+// optimal field order depends on the target architecture's word size and
+// alignment, rather than being universally correct.
+package xarch
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+const doc = `check for struct layouts that optimize differently per architecture
+
+This analyzer computes, for every struct, its size, alignment, and
+pointer-scan prefix under each of Go's everyday GOARCHes (386, arm, amd64,
+arm64, wasm, amd64p32). It reports structs where the field order that
+minimizes size on one architecture is not optimal on another, so the user
+can decide which target to optimize for instead of assuming a single
+"best" order.
+
+This is the "does the order disagree" half of the cross-architecture
+picture; see -format=xarch (internal/report) for the full per-struct,
+per-arch table this reports only a summary of.`
+
+// Analyzer reports structs whose optimal field order is architecture
+// dependent.
+var Analyzer = &analysis.Analyzer{
+	Name:     "xarch",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	nodeFilter := []ast.Node{(*ast.StructType)(nil)}
+
+	insp.Preorder(nodeFilter, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		if st.Fields == nil || len(st.Fields.List) < 2 {
+			return
+		}
+		if fieldsrc.Skip(pass.TypesInfo, st) {
+			return
+		}
+
+		structType, ok := pass.TypesInfo.TypeOf(st).(*types.Struct)
+		if !ok {
+			return
+		}
+
+		results := layout.CrossArch(structType)
+		if !layout.OrdersDiffer(results) {
+			return
+		}
+
+		pass.Report(analysis.Diagnostic{
+			Pos:     st.Pos(),
+			End:     st.End(),
+			Message: fmt.Sprintf("layout varies by target (size/align/ptr-bytes): %s; optimal field order differs across these architectures", table(results)),
+		})
+	})
+
+	return nil, nil
+}
+
+// table renders results as a compact "arch=size/align/ptr-bytes" list.
+func table(results []layout.ArchResult) string {
+	parts := make([]string, len(results))
+	for i, r := range results {
+		parts[i] = fmt.Sprintf("%s=%d/%d/%d", r.Arch, r.Size, r.Align, r.PointerBytes)
+	}
+	return strings.Join(parts, ", ")
+}
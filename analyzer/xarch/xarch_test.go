@@ -0,0 +1,14 @@
+package xarch_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/avifenesh/audit-struct/analyzer/xarch"
+)
+
+func TestAnalyzer(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.Run(t, testdata, xarch.Analyzer, "a")
+}
@@ -0,0 +1,123 @@
+// Command audit-struct reports Go struct declarations whose field order
+// wastes space to padding, unnecessary garbage-collector scanning, or
+// cross-goroutine cache-line contention, and flags layouts whose optimal
+// order depends on the target architecture.
+//
+// By default it runs as a go/analysis multichecker, printing diagnostics
+// in the usual vet/lint text format. Pass -format=json or -format=sarif to
+// instead print a full per-struct layout report for CI consumption; see
+// internal/report for the schema. Pass -format=xarch to print, for every
+// struct, its size/alignment/pointer-bytes under each of Go's everyday
+// GOARCHes (see internal/layout.Archs) -- the xarch analyzer above only
+// flags structs where that table disagrees on the optimal order; this
+// prints the table itself.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis/multichecker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/avifenesh/audit-struct/analyzer/atomicalign"
+	"github.com/avifenesh/audit-struct/analyzer/falsesharing"
+	"github.com/avifenesh/audit-struct/analyzer/fieldalign"
+	"github.com/avifenesh/audit-struct/analyzer/pointerbytes"
+	"github.com/avifenesh/audit-struct/analyzer/xarch"
+	"github.com/avifenesh/audit-struct/internal/report"
+)
+
+func main() {
+	format, rest := extractFormat(os.Args[1:])
+	os.Args = append(os.Args[:1], rest...)
+
+	switch format {
+	case "", "text":
+		multichecker.Main(
+			fieldalign.Analyzer,
+			pointerbytes.Analyzer,
+			falsesharing.Analyzer,
+			xarch.Analyzer,
+			atomicalign.Analyzer,
+		)
+	case "json", "sarif", "xarch":
+		if err := runReport(format, rest); err != nil {
+			fmt.Fprintln(os.Stderr, "audit-struct:", err)
+			os.Exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "audit-struct: unknown -format %q (want text, json, sarif, or xarch)\n", format)
+		os.Exit(2)
+	}
+}
+
+// extractFormat pulls a "-format"/"--format" flag (and its value, whether
+// given as a separate argument or joined with "=") out of args, returning
+// the requested format ("" if unset) and the remaining arguments
+// unmodified, so the rest can still be parsed as package patterns or
+// passed through to multichecker's own flags.
+func extractFormat(args []string) (format string, rest []string) {
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "-format" || a == "--format":
+			if i+1 < len(args) {
+				format = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "-format="):
+			format = strings.TrimPrefix(a, "-format=")
+		case strings.HasPrefix(a, "--format="):
+			format = strings.TrimPrefix(a, "--format=")
+		default:
+			rest = append(rest, a)
+		}
+	}
+	return format, rest
+}
+
+// runReport loads the packages named by patterns (defaulting to "./...")
+// and writes a layout report over stdout in the given format.
+func runReport(format string, patterns []string) error {
+	pkgs, err := loadPackages(patterns)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "json":
+		return report.WriteJSON(os.Stdout, report.Collect(pkgs))
+	case "sarif":
+		return report.WriteSARIF(os.Stdout, report.Collect(pkgs))
+	case "xarch":
+		return report.WriteXArchTable(os.Stdout, report.CollectXArch(pkgs))
+	default:
+		panic("unreachable")
+	}
+}
+
+// loadPackages loads the packages named by patterns (defaulting to
+// "./...") with enough information for internal/report to build a layout
+// report from their syntax trees.
+func loadPackages(patterns []string) ([]*packages.Package, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes,
+	}
+	pkgs, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("loading packages: %w", err)
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			return nil, e
+		}
+	}
+	return pkgs, nil
+}
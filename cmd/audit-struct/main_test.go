@@ -0,0 +1,74 @@
+package main_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// buildCLI compiles the audit-struct binary into t's temporary directory.
+func buildCLI(t *testing.T) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "audit-struct")
+	cmd := exec.Command("go", "build", "-o", bin, ".")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+	return bin
+}
+
+// TestFixDoesNotConflictAcrossAnalyzers guards against the multichecker
+// registering several struct-layout analyzers that each suggest a fix for
+// the very same struct: x/tools' checker machinery aborts the entire -fix
+// run -- applying none of its edits -- the moment two analyzers propose
+// overlapping edits to one file, and WithPointer below is exactly that
+// case (both pointerbytes, for its pointer-scan prefix, and fieldalign,
+// for its overall size, would otherwise want to reorder it).
+func TestFixDoesNotConflictAcrossAnalyzers(t *testing.T) {
+	bin := buildCLI(t)
+
+	src := filepath.Join(t.TempDir(), "withpointer.go")
+	const before = `package a
+
+type WithPointer struct {
+	tag   byte
+	ptr   *int
+	value int32
+}
+`
+	if err := os.WriteFile(src, []byte(before), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	cmd := exec.Command(bin, "-fix", src)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+
+	if strings.Contains(stderr.String(), "conflicting edits") {
+		t.Fatalf("-fix reported conflicting edits and applied nothing:\n%s", stderr.String())
+	}
+	// Exit status 3 (vet-style: diagnostics were found) is expected; only a
+	// failure to start, or the conflicting-edits abort above, is a problem.
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 3 {
+			t.Fatalf("running -fix: %v\nstderr:\n%s", err, stderr.String())
+		}
+	}
+
+	fixed, err := os.ReadFile(src)
+	if err != nil {
+		t.Fatalf("reading fixed file: %v", err)
+	}
+	if string(fixed) == before {
+		t.Errorf("-fix left the file unchanged, want WithPointer reordered")
+	}
+	for _, want := range []string{"tag", "ptr", "value", "type WithPointer struct"} {
+		if !strings.Contains(string(fixed), want) {
+			t.Errorf("fixed file missing %q, -fix may have corrupted the struct:\n%s", want, fixed)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+// Package fieldsrc provides shared helpers, used by every struct-layout
+// analyzer in this module, for grouping *ast.StructType fields into
+// orderable units and rewriting their source text once a new order has been
+// chosen.
+package fieldsrc
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// Group is one ast.Field declaration (which may declare several names
+// sharing a single type) together with the layout.Element describing it and
+// whether its type transitively contains a pointer.
+type Group struct {
+	Field      *ast.Field
+	Element    layout.Element
+	HasPointer bool
+}
+
+// Groups returns one Group per field declaration in st, in declaration
+// order. info resolves each field's type; sizes determines its layout.
+func Groups(info *types.Info, sizes types.Sizes, st *ast.StructType) []Group {
+	groups := make([]Group, len(st.Fields.List))
+	for i, f := range st.Fields.List {
+		t := info.TypeOf(f.Type)
+		count := len(f.Names)
+		if count == 0 {
+			count = 1 // embedded field
+		}
+		groups[i] = Group{
+			Field: f,
+			Element: layout.Element{
+				Align: sizes.Alignof(t),
+				Size:  sizes.Sizeof(t),
+				Count: count,
+			},
+			HasPointer: layout.HasPointer(t),
+		}
+	}
+	return groups
+}
+
+// Elements extracts the layout.Element of each group, in order.
+func Elements(groups []Group) []layout.Element {
+	elems := make([]layout.Element, len(groups))
+	for i, g := range groups {
+		elems[i] = g.Element
+	}
+	return elems
+}
+
+// Skip reports whether st must be left untouched by any reordering fix:
+// either it embeds the runtime's align64 padding marker (used to force
+// 8-byte alignment on 32-bit systems, and order-sensitive by construction),
+// or one of its fields has a type that failed to resolve.
+func Skip(info *types.Info, st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		t := info.TypeOf(f.Type)
+		if t == nil {
+			return true
+		}
+		if basic, ok := t.(*types.Basic); ok && basic.Kind() == types.Invalid {
+			return true
+		}
+		if named, ok := t.(*types.Named); ok {
+			obj := named.Obj()
+			if obj != nil && obj.Pkg() != nil && obj.Pkg().Path() == "sync/atomic" && obj.Name() == "align64" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Render rebuilds the struct's source text with its fields in the given
+// order. Rather than re-printing the AST (whose node positions would
+// confuse go/printer's line-spacing heuristics once fields are reordered),
+// it slices each field's original source text — including any doc comment
+// and struct tag — straight out of content and reassembles them between the
+// struct's original opening and closing braces. This keeps comments,
+// docstrings, and tags byte-for-byte intact.
+func Render(fset *token.FileSet, content []byte, st *ast.StructType, groups []Group, order []int) []byte {
+	offset := func(p token.Pos) int { return fset.Position(p).Offset }
+
+	var buf bytes.Buffer
+	buf.Write(content[offset(st.Pos()) : offset(st.Fields.Opening)+1]) // "struct {"
+	buf.WriteByte('\n')
+	for _, idx := range order {
+		f := groups[idx].Field
+		start := f.Pos()
+		if f.Doc != nil {
+			start = f.Doc.Pos()
+		}
+		end := f.End()
+		if f.Comment != nil {
+			end = f.Comment.End()
+		}
+		buf.Write(content[offset(start):offset(end)])
+		buf.WriteByte('\n')
+	}
+	buf.Write(content[offset(st.Fields.Closing):offset(st.End())]) // "}"
+	return buf.Bytes()
+}
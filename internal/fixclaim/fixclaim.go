@@ -0,0 +1,36 @@
+// Package fixclaim lets the struct-layout analyzers in this module avoid
+// proposing overlapping edits to the same struct. x/tools' checker
+// machinery aborts an entire -fix run -- applying none of its edits -- the
+// moment two analyzers suggest overlapping fixes for the same file, and
+// several of these analyzers often fire on the very same struct (e.g. a
+// GC-scan-prefix win is frequently also a size win). So at most one
+// analyzer may claim a given struct's fix; the rest still report their
+// diagnostic, just without a SuggestedFix.
+//
+// Analyzers that want to participate order themselves into a priority
+// chain: each Requires the next-higher-priority analyzer and inspects its
+// Result (a Set) to see what's already claimed, then returns the union of
+// that Set with whatever it claims itself, so the next analyzer up the
+// chain only needs to look at its one direct dependency.
+package fixclaim
+
+import "go/token"
+
+// Set is the struct positions (an *ast.StructType's Pos()) already claimed
+// by a higher-priority analyzer's SuggestedFix.
+type Set map[token.Pos]bool
+
+// Claims reports whether pos has already been claimed.
+func (s Set) Claims(pos token.Pos) bool {
+	return s[pos]
+}
+
+// Add returns a new Set containing every position in s plus pos.
+func (s Set) Add(pos token.Pos) Set {
+	out := make(Set, len(s)+1)
+	for p := range s {
+		out[p] = true
+	}
+	out[pos] = true
+	return out
+}
@@ -0,0 +1,68 @@
+package layout
+
+import "go/types"
+
+// Archs lists the GOARCHes (under the "gc" compiler) this package's
+// cross-architecture report covers: the everyday word/alignment
+// combinations, from the historic 32-bit targets through 64-bit and wasm,
+// plus amd64p32's word=4/maxalign=8 model -- the one combination none of
+// the other five archs exercise, since it pairs a 32-bit word size with
+// 64-bit alignment.
+var Archs = []string{"386", "arm", "amd64", "arm64", "wasm", "amd64p32"}
+
+// SizesFor returns the types.Sizes model for one of Archs.
+func SizesFor(arch string) types.Sizes {
+	return types.SizesFor("gc", arch)
+}
+
+// ArchResult holds one architecture's layout facts for a struct: its
+// current size, alignment, and pointer-scan prefix, plus the field order
+// that minimizes its size, all computed under that architecture's sizes
+// model.
+type ArchResult struct {
+	Arch         string
+	Size         int64
+	Align        int64
+	PointerBytes int64
+	OptimalOrder []int
+}
+
+// CrossArch computes an ArchResult for s under each of Archs.
+func CrossArch(s *types.Struct) []ArchResult {
+	results := make([]ArchResult, len(Archs))
+	for i, arch := range Archs {
+		sizes := SizesFor(arch)
+		order, _ := OptimalSize(FromStruct(s, sizes))
+		results[i] = ArchResult{
+			Arch:         arch,
+			Size:         CurrentSize(s, sizes),
+			Align:        sizes.Alignof(s),
+			PointerBytes: PointerBytes(s, sizes),
+			OptimalOrder: order,
+		}
+	}
+	return results
+}
+
+// OrdersDiffer reports whether results, as produced by CrossArch, disagree
+// on the optimal field order.
+func OrdersDiffer(results []ArchResult) bool {
+	for i := 1; i < len(results); i++ {
+		if !equalOrder(results[i].OptimalOrder, results[0].OptimalOrder) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalOrder(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
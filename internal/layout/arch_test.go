@@ -0,0 +1,37 @@
+package layout_test
+
+import (
+	"go/types"
+	"testing"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+func TestCrossArchFlagsOrderDependentLayout(t *testing.T) {
+	// Mixed: a fixed-size array competes with a pointer for the widest
+	// alignment. On 64-bit targets the pointer (word-aligned) sorts
+	// first; on 32-bit targets it ties the array's 4-byte alignment and
+	// loses the size tiebreak, so the optimal order flips.
+	mixed := newStruct(
+		field("arr", types.NewArray(types.Typ[types.Int32], 2)),
+		field("ptr", types.NewPointer(types.Typ[types.Int])),
+		field("flag", types.Typ[types.Bool]),
+	)
+	results := layout.CrossArch(mixed)
+	if len(results) != len(layout.Archs) {
+		t.Fatalf("CrossArch returned %d results, want %d", len(results), len(layout.Archs))
+	}
+	if !layout.OrdersDiffer(results) {
+		t.Errorf("OrdersDiffer(CrossArch(mixed)) = false, want true")
+	}
+
+	// AllInts has no pointers, so its optimal order is arch independent.
+	allInts := newStruct(
+		field("small", types.Typ[types.Uint8]),
+		field("big", types.Typ[types.Uint64]),
+		field("mid", types.Typ[types.Uint32]),
+	)
+	if layout.OrdersDiffer(layout.CrossArch(allInts)) {
+		t.Errorf("OrdersDiffer(CrossArch(allInts)) = true, want false")
+	}
+}
@@ -0,0 +1,95 @@
+// Package layout computes struct memory layout: field offsets, padding, and
+// total size under a given types.Sizes model, and can propose a field order
+// that minimizes size while preserving field identity.
+package layout
+
+import "sort"
+
+// Element describes one orderable unit of a struct for layout purposes: a
+// single field, or a group of fields declared together with the same type
+// (e.g. "a, b, c, d bool"). Count is the number of fields the element
+// represents; Align and Size describe its type.
+type Element struct {
+	Align int64
+	Size  int64
+	Count int
+}
+
+// Simulate lays out elems back-to-back in the given order and returns the
+// resulting struct size, including trailing padding to the struct's overall
+// alignment. It mirrors the layout algorithm the compiler applies to struct
+// fields: each field starts at the next offset that satisfies its own
+// alignment, and the final size is rounded up to the widest field alignment.
+func Simulate(elems []Element) int64 {
+	var offset int64
+	var maxAlign int64 = 1
+	for _, e := range elems {
+		if e.Align > maxAlign {
+			maxAlign = e.Align
+		}
+		for i := 0; i < e.Count; i++ {
+			offset = alignUp(offset, e.Align)
+			offset += e.Size
+		}
+	}
+	return alignUp(offset, maxAlign)
+}
+
+// OptimalOrder returns a permutation of elems' indices, sorted by descending
+// alignment and then descending size, that minimizes the resulting struct
+// size. Ties are broken by original declaration order (the sort is stable),
+// and zero-sized elements are always placed last so that
+// trailing-empty-field semantics (e.g. a field aliased to the address just
+// past the struct) are preserved.
+func OptimalOrder(elems []Element) []int {
+	order := make([]int, len(elems))
+	for i := range order {
+		order[i] = i
+	}
+	zero := func(i int) bool { return elems[i].Size == 0 }
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		if zero(i) != zero(j) {
+			return !zero(i)
+		}
+		if elems[i].Align != elems[j].Align {
+			return elems[i].Align > elems[j].Align
+		}
+		return elems[i].Size > elems[j].Size
+	})
+	return order
+}
+
+// Offsets returns the starting byte offset of each unit elems lays out to,
+// in the given order, expanding grouped elements (Count > 1) into one
+// offset per unit they represent.
+func Offsets(elems []Element) []int64 {
+	var offsets []int64
+	var offset int64
+	for _, e := range elems {
+		for i := 0; i < e.Count; i++ {
+			offset = alignUp(offset, e.Align)
+			offsets = append(offsets, offset)
+			offset += e.Size
+		}
+	}
+	return offsets
+}
+
+// OptimalSize returns the element order from OptimalOrder together with the
+// struct size that order produces.
+func OptimalSize(elems []Element) ([]int, int64) {
+	order := OptimalOrder(elems)
+	reordered := make([]Element, len(order))
+	for i, idx := range order {
+		reordered[i] = elems[idx]
+	}
+	return order, Simulate(reordered)
+}
+
+func alignUp(offset, align int64) int64 {
+	if align <= 1 {
+		return offset
+	}
+	return (offset + align - 1) / align * align
+}
@@ -0,0 +1,113 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+func TestSimulate(t *testing.T) {
+	// bool, uint64, uint8, uint32 in declaration order: 1+7pad, 8, 1+3pad, 4 = 24.
+	poorlyAligned := []layout.Element{
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 8, Size: 8, Count: 1},
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 4, Size: 4, Count: 1},
+	}
+	if got := layout.Simulate(poorlyAligned); got != 24 {
+		t.Errorf("Simulate(poorlyAligned) = %d, want 24", got)
+	}
+
+	// uint64, uint32, uint8, bool: 8, 4, 1, 1 = 14, rounded up to 16.
+	wellAligned := []layout.Element{
+		{Align: 8, Size: 8, Count: 1},
+		{Align: 4, Size: 4, Count: 1},
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 1, Size: 1, Count: 1},
+	}
+	if got := layout.Simulate(wellAligned); got != 16 {
+		t.Errorf("Simulate(wellAligned) = %d, want 16", got)
+	}
+}
+
+func TestOptimalOrder(t *testing.T) {
+	elems := []layout.Element{
+		{Align: 1, Size: 1, Count: 1}, // 0: flag
+		{Align: 8, Size: 8, Count: 1}, // 1: bigValue
+		{Align: 1, Size: 1, Count: 1}, // 2: small
+		{Align: 4, Size: 4, Count: 1}, // 3: medium
+	}
+	order := layout.OptimalOrder(elems)
+	want := []int{1, 3, 0, 2}
+	if len(order) != len(want) {
+		t.Fatalf("OptimalOrder returned %d indices, want %d", len(order), len(want))
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("OptimalOrder()[%d] = %d, want %d (full: %v)", i, order[i], want[i], order)
+		}
+	}
+}
+
+func TestOptimalSize(t *testing.T) {
+	elems := []layout.Element{
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 8, Size: 8, Count: 1},
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 4, Size: 4, Count: 1},
+	}
+	_, size := layout.OptimalSize(elems)
+	if size != 16 {
+		t.Errorf("OptimalSize size = %d, want 16", size)
+	}
+}
+
+func TestOptimalOrderKeepsZeroSizedFieldsLast(t *testing.T) {
+	elems := []layout.Element{
+		{Align: 1, Size: 0, Count: 1}, // 0: zero-sized
+		{Align: 8, Size: 8, Count: 1}, // 1
+		{Align: 4, Size: 4, Count: 1}, // 2
+	}
+	order := layout.OptimalOrder(elems)
+	if order[len(order)-1] != 0 {
+		t.Errorf("OptimalOrder() = %v, want zero-sized element (index 0) last", order)
+	}
+}
+
+func TestOffsets(t *testing.T) {
+	// bool, uint64, uint8, uint32 in declaration order: 1+7pad, 8, 1+3pad, 4.
+	elems := []layout.Element{
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 8, Size: 8, Count: 1},
+		{Align: 1, Size: 1, Count: 1},
+		{Align: 4, Size: 4, Count: 1},
+	}
+	want := []int64{0, 8, 16, 20}
+	got := layout.Offsets(elems)
+	if len(got) != len(want) {
+		t.Fatalf("Offsets returned %d offsets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Offsets()[%d] = %d, want %d (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
+
+func TestOffsetsExpandsGroupedElements(t *testing.T) {
+	// "a, b, c, d bool" declared together, then a trailing uint32.
+	elems := []layout.Element{
+		{Align: 1, Size: 1, Count: 4},
+		{Align: 4, Size: 4, Count: 1},
+	}
+	want := []int64{0, 1, 2, 3, 4}
+	got := layout.Offsets(elems)
+	if len(got) != len(want) {
+		t.Fatalf("Offsets returned %d offsets, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Offsets()[%d] = %d, want %d (full: %v)", i, got[i], want[i], got)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package layout
+
+import "go/types"
+
+// PointerBytes returns the number of leading bytes of s that the garbage
+// collector must scan for pointers: the offset of the last pointer-bearing
+// word plus one word, or 0 if s contains no pointers at all. Bytes after
+// that point are guaranteed pointer-free and are skipped during scanning,
+// so laying out pointer-containing fields before scalar-only fields
+// minimizes this value.
+func PointerBytes(s *types.Struct, sizes types.Sizes) int64 {
+	return pointerExtent(s, sizes)
+}
+
+// HasPointer reports whether t transitively contains a pointer, slice, map,
+// channel, function, interface, or string — i.e. any type the garbage
+// collector must scan.
+func HasPointer(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return true
+	case *types.Basic:
+		return u.Kind() == types.String || u.Kind() == types.UnsafePointer
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			if HasPointer(u.Field(i).Type()) {
+				return true
+			}
+		}
+		return false
+	case *types.Array:
+		return u.Len() > 0 && HasPointer(u.Elem())
+	default:
+		return false
+	}
+}
+
+// pointerExtent returns the number of leading bytes of t that must be
+// scanned for pointers, laid out under sizes.
+func pointerExtent(t types.Type, sizes types.Sizes) int64 {
+	word := sizes.Sizeof(types.Typ[types.UnsafePointer])
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer, *types.Map, *types.Chan, *types.Signature:
+		return word
+	case *types.Slice:
+		return word // only the data pointer is scanned; len/cap are not
+	case *types.Interface:
+		return sizes.Sizeof(t) // both the type word and the data word are scanned
+	case *types.Basic:
+		if u.Kind() == types.String || u.Kind() == types.UnsafePointer {
+			return word
+		}
+		return 0
+	case *types.Struct:
+		var offset, maxAlign, lastEnd int64 = 0, 1, 0
+		for i := 0; i < u.NumFields(); i++ {
+			ft := u.Field(i).Type()
+			a := sizes.Alignof(ft)
+			if a > maxAlign {
+				maxAlign = a
+			}
+			offset = alignUp(offset, a)
+			if ext := pointerExtent(ft, sizes); ext > 0 && offset+ext > lastEnd {
+				lastEnd = offset + ext
+			}
+			offset += sizes.Sizeof(ft)
+		}
+		return lastEnd
+	case *types.Array:
+		if u.Len() == 0 {
+			return 0
+		}
+		elemExtent := pointerExtent(u.Elem(), sizes)
+		if elemExtent == 0 {
+			return 0
+		}
+		return (u.Len()-1)*sizes.Sizeof(u.Elem()) + elemExtent
+	default:
+		return 0
+	}
+}
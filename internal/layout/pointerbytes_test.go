@@ -0,0 +1,72 @@
+package layout_test
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+func amd64() types.Sizes { return types.SizesFor("gc", "amd64") }
+
+func newStruct(fields ...*types.Var) *types.Struct {
+	tags := make([]string, len(fields))
+	return types.NewStruct(fields, tags)
+}
+
+func field(name string, t types.Type) *types.Var {
+	return types.NewField(token.NoPos, nil, name, t, false)
+}
+
+func TestHasPointer(t *testing.T) {
+	intPtr := types.NewPointer(types.Typ[types.Int])
+	cases := []struct {
+		name string
+		typ  types.Type
+		want bool
+	}{
+		{"int", types.Typ[types.Int], false},
+		{"bool", types.Typ[types.Bool], false},
+		{"string", types.Typ[types.String], true},
+		{"pointer", intPtr, true},
+		{"slice", types.NewSlice(types.Typ[types.Int]), true},
+		{"map", types.NewMap(types.Typ[types.String], types.Typ[types.Int]), true},
+		{"struct with pointer", newStruct(field("p", intPtr)), true},
+		{"struct without pointer", newStruct(field("n", types.Typ[types.Int])), false},
+	}
+	for _, c := range cases {
+		if got := layout.HasPointer(c.typ); got != c.want {
+			t.Errorf("HasPointer(%s) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestPointerBytes(t *testing.T) {
+	sizes := amd64()
+
+	// WithSlice: count int (8), items []int (24, ptr in first word).
+	withSlice := newStruct(
+		field("count", types.Typ[types.Int]),
+		field("items", types.NewSlice(types.Typ[types.Int])),
+	)
+	if got, want := layout.PointerBytes(withSlice, sizes), int64(16); got != want {
+		t.Errorf("PointerBytes(WithSlice) = %d, want %d", got, want)
+	}
+
+	// WithPointer: tag byte (1+7pad), ptr *int (8), value int32 (4).
+	withPointer := newStruct(
+		field("tag", types.Typ[types.Byte]),
+		field("ptr", types.NewPointer(types.Typ[types.Int])),
+		field("value", types.Typ[types.Int32]),
+	)
+	if got, want := layout.PointerBytes(withPointer, sizes), int64(16); got != want {
+		t.Errorf("PointerBytes(WithPointer) = %d, want %d", got, want)
+	}
+
+	// No pointers at all.
+	noPointers := newStruct(field("a", types.Typ[types.Int]), field("b", types.Typ[types.Bool]))
+	if got, want := layout.PointerBytes(noPointers, sizes), int64(0); got != want {
+		t.Errorf("PointerBytes(noPointers) = %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,29 @@
+package layout
+
+import "go/types"
+
+// FromStruct expands a *types.Struct into one Element per field, using sizes
+// to compute each field's alignment and size. Unlike the AST-level callers
+// in analyzer/fieldalign, this operates on the type system's flattened field
+// list, so every Element has Count 1.
+func FromStruct(s *types.Struct, sizes types.Sizes) []Element {
+	elems := make([]Element, s.NumFields())
+	for i := range elems {
+		t := s.Field(i).Type()
+		elems[i] = Element{
+			Align: sizes.Alignof(t),
+			Size:  sizes.Sizeof(t),
+			Count: 1,
+		}
+	}
+	return elems
+}
+
+// CurrentSize returns the size of s as laid out today, i.e. with its fields
+// in declaration order. This goes through Simulate rather than
+// sizes.Sizeof(s) directly: go/types' Sizeof for a struct stops at the
+// last field's end and does not round up to the struct's own alignment,
+// so it can under-report the size of a struct that needs trailing padding.
+func CurrentSize(s *types.Struct, sizes types.Sizes) int64 {
+	return Simulate(FromStruct(s, sizes))
+}
@@ -0,0 +1,24 @@
+package report
+
+import (
+	"go/ast"
+	"os"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// suggestedFix renders st's source with its fields reordered to minimize
+// size, the same way analyzer/fieldalign builds its SuggestedFix. It
+// returns "" if the source can't be read.
+func suggestedFix(pkg *packages.Package, st *ast.StructType) string {
+	content, err := os.ReadFile(pkg.Fset.Position(st.Pos()).Filename)
+	if err != nil {
+		return ""
+	}
+	groups := fieldsrc.Groups(pkg.TypesInfo, pkg.TypesSizes, st)
+	order, _ := layout.OptimalSize(fieldsrc.Elements(groups))
+	return string(fieldsrc.Render(pkg.Fset, content, st, groups, order))
+}
@@ -0,0 +1,148 @@
+// Package report builds a machine-readable snapshot of every named struct
+// declaration in a set of loaded packages -- its layout, its pointer-scan
+// prefix, and the field order that would minimize its size -- so it can be
+// rendered as JSON or SARIF for CI consumption.
+package report
+
+import (
+	"go/ast"
+	"go/types"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/avifenesh/audit-struct/internal/fieldsrc"
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// Field describes one named field of a reported struct, in its current
+// (declaration-order) layout.
+type Field struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Offset        int64  `json:"offset"`
+	Size          int64  `json:"size"`
+	Align         int64  `json:"align"`
+	PaddingBefore int64  `json:"padding_before"`
+}
+
+// Struct describes one named struct type declaration's layout.
+type Struct struct {
+	File           string   `json:"file"`
+	Line           int      `json:"line"`
+	Name           string   `json:"name"`
+	CurrentSize    int64    `json:"current_size"`
+	OptimalSize    int64    `json:"optimal_size"`
+	PointerBytes   int64    `json:"pointer_bytes"`
+	Fields         []Field  `json:"fields"`
+	SuggestedOrder []string `json:"suggested_order,omitempty"`
+
+	// fix is the AST-rewritten struct source with fields reordered to
+	// SuggestedOrder, computed the same way analyzer/fieldalign computes
+	// its SuggestedFix. It spans exactly st.Pos() to st.End(), so
+	// startCol/endLine/endCol -- the column st.Pos() starts on and the
+	// line/column st.End() starts on -- locate precisely the region fix
+	// replaces; Line/startCol alone default to whole lines, which would
+	// span the "type Name " prefix that isn't part of fix. All four are
+	// empty/zero when SuggestedOrder is empty, and are omitted from JSON --
+	// only the SARIF writer consumes them.
+	fix      string
+	startCol int
+	endLine  int
+	endCol   int
+}
+
+// Collect walks every loaded package's syntax trees and returns one Struct
+// per named struct type declaration, sorted by file and then line.
+func Collect(pkgs []*packages.Package) []Struct {
+	var out []Struct
+	walkStructs(pkgs, func(pkg *packages.Package, name string, st *ast.StructType, structType *types.Struct) {
+		out = append(out, build(pkg, name, st, structType))
+	})
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
+
+// walkStructs calls fn once for every named struct type declaration across
+// pkgs' syntax trees, skipping the same order-sensitive or unresolved
+// declarations fieldsrc.Skip excludes from every other analyzer in this
+// module.
+func walkStructs(pkgs []*packages.Package, fn func(pkg *packages.Package, name string, st *ast.StructType, structType *types.Struct)) {
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				ts, ok := n.(*ast.TypeSpec)
+				if !ok {
+					return true
+				}
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok || st.Fields == nil || len(st.Fields.List) == 0 {
+					return true
+				}
+				if fieldsrc.Skip(pkg.TypesInfo, st) {
+					return true
+				}
+				structType, ok := pkg.TypesInfo.TypeOf(st).(*types.Struct)
+				if !ok {
+					return true
+				}
+				fn(pkg, ts.Name.Name, st, structType)
+				return true
+			})
+		}
+	}
+}
+
+func build(pkg *packages.Package, name string, st *ast.StructType, structType *types.Struct) Struct {
+	sizes := pkg.TypesSizes
+	pos := pkg.Fset.Position(st.Pos())
+
+	elems := layout.FromStruct(structType, sizes)
+	offsets := layout.Offsets(elems)
+	fields := make([]Field, structType.NumFields())
+	for i := range fields {
+		f := structType.Field(i)
+		prevEnd := int64(0)
+		if i > 0 {
+			prevEnd = offsets[i-1] + elems[i-1].Size
+		}
+		fields[i] = Field{
+			Name:          f.Name(),
+			Type:          f.Type().String(),
+			Offset:        offsets[i],
+			Size:          elems[i].Size,
+			Align:         elems[i].Align,
+			PaddingBefore: offsets[i] - prevEnd,
+		}
+	}
+
+	currentSize := layout.CurrentSize(structType, sizes)
+	order, optimalSize := layout.OptimalSize(elems)
+
+	s := Struct{
+		File:         pos.Filename,
+		Line:         pos.Line,
+		Name:         name,
+		CurrentSize:  currentSize,
+		OptimalSize:  optimalSize,
+		PointerBytes: layout.PointerBytes(structType, sizes),
+		Fields:       fields,
+	}
+	if optimalSize < currentSize {
+		s.SuggestedOrder = make([]string, len(order))
+		for i, idx := range order {
+			s.SuggestedOrder[i] = structType.Field(idx).Name()
+		}
+		s.fix = suggestedFix(pkg, st)
+		s.startCol = pos.Column
+		endPos := pkg.Fset.Position(st.End())
+		s.endLine = endPos.Line
+		s.endCol = endPos.Column
+	}
+	return s
+}
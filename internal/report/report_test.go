@@ -0,0 +1,203 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+	"github.com/avifenesh/audit-struct/internal/report"
+)
+
+func loadTestdata(t *testing.T) []*packages.Package {
+	t.Helper()
+	cfg := &packages.Config{
+		Dir: "testdata/src/a",
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+			packages.NeedTypes | packages.NeedTypesInfo | packages.NeedTypesSizes,
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		t.Fatalf("packages.Load: %v", err)
+	}
+	for _, pkg := range pkgs {
+		for _, e := range pkg.Errors {
+			t.Fatalf("package error: %v", e)
+		}
+	}
+	return pkgs
+}
+
+func TestCollect(t *testing.T) {
+	structs := report.Collect(loadTestdata(t))
+	if len(structs) != 1 {
+		t.Fatalf("Collect returned %d structs, want 1", len(structs))
+	}
+
+	s := structs[0]
+	if s.Name != "PoorlyAligned" {
+		t.Errorf("Name = %q, want PoorlyAligned", s.Name)
+	}
+	if s.CurrentSize != 24 {
+		t.Errorf("CurrentSize = %d, want 24", s.CurrentSize)
+	}
+	if s.OptimalSize != 16 {
+		t.Errorf("OptimalSize = %d, want 16", s.OptimalSize)
+	}
+	wantOrder := []string{"bigValue", "medium", "flag", "small"}
+	if len(s.SuggestedOrder) != len(wantOrder) {
+		t.Fatalf("SuggestedOrder = %v, want %v", s.SuggestedOrder, wantOrder)
+	}
+	for i, name := range wantOrder {
+		if s.SuggestedOrder[i] != name {
+			t.Errorf("SuggestedOrder[%d] = %q, want %q", i, s.SuggestedOrder[i], name)
+		}
+	}
+	if len(s.Fields) != 4 || s.Fields[1].Name != "bigValue" || s.Fields[1].PaddingBefore != 7 {
+		t.Errorf("Fields = %+v, want bigValue at index 1 with 7 bytes padding_before", s.Fields)
+	}
+}
+
+func TestWriteJSONContainsFields(t *testing.T) {
+	structs := report.Collect(loadTestdata(t))
+	var buf bytes.Buffer
+	if err := report.WriteJSON(&buf, structs); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{`"name": "PoorlyAligned"`, `"current_size": 24`, `"padding_before"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteJSON output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteSARIFContainsFix(t *testing.T) {
+	structs := report.Collect(loadTestdata(t))
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, structs); err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{`"ruleId": "reorder-fields"`, `"insertedContent"`, `bigValue uint64`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("WriteSARIF output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestCollectXArch(t *testing.T) {
+	structs := report.CollectXArch(loadTestdata(t))
+	if len(structs) != 1 {
+		t.Fatalf("CollectXArch returned %d structs, want 1", len(structs))
+	}
+
+	s := structs[0]
+	if s.Name != "PoorlyAligned" {
+		t.Errorf("Name = %q, want PoorlyAligned", s.Name)
+	}
+	if len(s.Rows) != len(layout.Archs) {
+		t.Fatalf("Rows has %d entries, want %d (one per layout.Archs)", len(s.Rows), len(layout.Archs))
+	}
+	for i, r := range s.Rows {
+		if r.Arch != layout.Archs[i] {
+			t.Errorf("Rows[%d].Arch = %q, want %q", i, r.Arch, layout.Archs[i])
+		}
+		if r.Size <= 0 || r.Align <= 0 {
+			t.Errorf("Rows[%d] = %+v, want positive size and align", i, r)
+		}
+	}
+}
+
+func TestWriteXArchTable(t *testing.T) {
+	structs := report.CollectXArch(loadTestdata(t))
+	var buf bytes.Buffer
+	if err := report.WriteXArchTable(&buf, structs); err != nil {
+		t.Fatalf("WriteXArchTable: %v", err)
+	}
+	out := buf.String()
+	for _, arch := range layout.Archs {
+		if !strings.Contains(out, "PoorlyAligned\t"+arch+"\t") {
+			t.Errorf("WriteXArchTable output missing a PoorlyAligned row for %s:\n%s", arch, out)
+		}
+	}
+}
+
+// sarifRegion and friends mirror just enough of the SARIF schema to read
+// back the deletedRegion this package writes.
+type sarifRegion struct {
+	StartLine, StartColumn, EndLine, EndColumn int
+}
+
+type sarifDoc struct {
+	Runs []struct {
+		Results []struct {
+			Fixes []struct {
+				ArtifactChanges []struct {
+					Replacements []struct {
+						DeletedRegion   sarifRegion
+						InsertedContent struct{ Text string }
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestWriteSARIFFixRegionMatchesInsertedContent guards against the
+// deletedRegion defaulting to whole lines: with no startColumn/endColumn,
+// applying the fix would replace the entire "type PoorlyAligned struct {"
+// line, including the "type PoorlyAligned " prefix that insertedContent
+// doesn't carry, which would drop the type's name.
+func TestWriteSARIFFixRegionMatchesInsertedContent(t *testing.T) {
+	structs := report.Collect(loadTestdata(t))
+	var buf bytes.Buffer
+	if err := report.WriteSARIF(&buf, structs); err != nil {
+		t.Fatalf("WriteSARIF: %v", err)
+	}
+
+	var doc sarifDoc
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal SARIF output: %v", err)
+	}
+	repl := doc.Runs[0].Results[0].Fixes[0].ArtifactChanges[0].Replacements[0]
+	region := repl.DeletedRegion
+	if region.StartColumn == 0 || region.EndColumn == 0 {
+		t.Fatalf("deletedRegion %+v has no column, so it spans whole lines and would delete the \"type PoorlyAligned \" prefix", region)
+	}
+
+	src, err := os.ReadFile("testdata/src/a/a.go")
+	if err != nil {
+		t.Fatalf("reading testdata source: %v", err)
+	}
+	lines := strings.Split(string(src), "\n")
+	deleted := extractRegion(lines, region)
+
+	if strings.Contains(deleted, "type PoorlyAligned") {
+		t.Errorf("deletedRegion %q includes the type name, so applying insertedContent %q would drop it", deleted, repl.InsertedContent.Text)
+	}
+	if !strings.HasPrefix(deleted, "struct {") {
+		t.Errorf("deletedRegion = %q, want it to start exactly at \"struct {\"", deleted)
+	}
+}
+
+// extractRegion returns the text a SARIF region spans, given the file's
+// lines split on "\n" and 1-based line/column coordinates.
+func extractRegion(lines []string, r sarifRegion) string {
+	if r.StartLine == r.EndLine {
+		return lines[r.StartLine-1][r.StartColumn-1 : r.EndColumn-1]
+	}
+	var b strings.Builder
+	b.WriteString(lines[r.StartLine-1][r.StartColumn-1:])
+	for i := r.StartLine; i < r.EndLine-1; i++ {
+		b.WriteByte('\n')
+		b.WriteString(lines[i])
+	}
+	b.WriteByte('\n')
+	b.WriteString(lines[r.EndLine-1][:r.EndColumn-1])
+	return b.String()
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// sarifLog is a minimal SARIF 2.1.0 log sufficient to carry one rule and
+// one result per struct that could be made smaller by reordering fields,
+// each with a replacement-text fix that tools like GitHub code scanning
+// can surface as a suggested change.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string    `json:"id"`
+	ShortDescription sarifText `json:"shortDescription"`
+}
+
+type sarifText struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifText       `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	Fixes     []sarifFix      `json:"fixes"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifRegion identifies a span of source text. StartColumn/EndColumn are
+// omitted where a whole-line span is intended; per the SARIF 2.1.0 spec, an
+// omitted column defaults to the full line. A deletedRegion paired with
+// insertedContent must set both, or a consumer applying the fix will
+// replace the full startLine..endLine span -- including anything before
+// startColumn and after endColumn -- with content that doesn't account for
+// it.
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifFix struct {
+	Description     sarifText             `json:"description"`
+	ArtifactChanges []sarifArtifactChange `json:"artifactChanges"`
+}
+
+type sarifArtifactChange struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Replacements     []sarifReplacement    `json:"replacements"`
+}
+
+type sarifReplacement struct {
+	DeletedRegion   sarifRegion          `json:"deletedRegion"`
+	InsertedContent sarifInsertedContent `json:"insertedContent"`
+}
+
+type sarifInsertedContent struct {
+	Text string `json:"text"`
+}
+
+const reorderFieldsRuleID = "reorder-fields"
+
+// WriteSARIF writes structs as a SARIF 2.1.0 log, one result per struct
+// whose fields can be reordered to reduce its size.
+func WriteSARIF(w io.Writer, structs []Struct) error {
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name: "audit-struct",
+				Rules: []sarifRule{{
+					ID:               reorderFieldsRuleID,
+					ShortDescription: sarifText{Text: "struct fields could be reordered to reduce size"},
+				}},
+			}},
+		}},
+	}
+
+	for _, s := range structs {
+		if s.fix == "" {
+			continue
+		}
+		log.Runs[0].Results = append(log.Runs[0].Results, sarifResult{
+			RuleID: reorderFieldsRuleID,
+			Level:  "warning",
+			Message: sarifText{Text: fmt.Sprintf(
+				"%s is %d bytes, could be %d after reordering fields to %v",
+				s.Name, s.CurrentSize, s.OptimalSize, s.SuggestedOrder,
+			)},
+			Locations: []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifactLocation{URI: s.File},
+				Region:           sarifRegion{StartLine: s.Line, EndLine: s.endLine},
+			}}},
+			Fixes: []sarifFix{{
+				Description: sarifText{Text: "reorder fields to minimize size"},
+				ArtifactChanges: []sarifArtifactChange{{
+					ArtifactLocation: sarifArtifactLocation{URI: s.File},
+					Replacements: []sarifReplacement{{
+						DeletedRegion:   sarifRegion{StartLine: s.Line, StartColumn: s.startCol, EndLine: s.endLine, EndColumn: s.endCol},
+						InsertedContent: sarifInsertedContent{Text: s.fix},
+					}},
+				}},
+			}},
+		})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
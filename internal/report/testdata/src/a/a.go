@@ -0,0 +1,9 @@
+package a
+
+// PoorlyAligned has internal padding due to field ordering.
+type PoorlyAligned struct {
+	flag     bool
+	bigValue uint64
+	small    uint8
+	medium   uint32
+}
@@ -0,0 +1,61 @@
+package report
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"io"
+	"sort"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/avifenesh/audit-struct/internal/layout"
+)
+
+// XArchStruct is one named struct's layout.CrossArch results, keyed to the
+// declaration that produced them.
+type XArchStruct struct {
+	File string
+	Line int
+	Name string
+	Rows []layout.ArchResult
+}
+
+// CollectXArch walks every loaded package's syntax trees and returns one
+// XArchStruct per named struct type declaration, each carrying its size,
+// alignment, and pointer-scan prefix under every architecture in
+// layout.Archs, sorted by file and then line.
+func CollectXArch(pkgs []*packages.Package) []XArchStruct {
+	var out []XArchStruct
+	walkStructs(pkgs, func(pkg *packages.Package, name string, st *ast.StructType, structType *types.Struct) {
+		pos := pkg.Fset.Position(st.Pos())
+		out = append(out, XArchStruct{
+			File: pos.Filename,
+			Line: pos.Line,
+			Name: name,
+			Rows: layout.CrossArch(structType),
+		})
+	})
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].File != out[j].File {
+			return out[i].File < out[j].File
+		}
+		return out[i].Line < out[j].Line
+	})
+	return out
+}
+
+// WriteXArchTable writes structs as a plain-text table of each struct's
+// size, alignment, and pointer-scan prefix under every architecture in
+// layout.Archs, one line per struct per architecture.
+func WriteXArchTable(w io.Writer, structs []XArchStruct) error {
+	for _, s := range structs {
+		for _, r := range s.Rows {
+			if _, err := fmt.Fprintf(w, "%s:%d\t%s\t%s\tsize=%d\talign=%d\tptr-bytes=%d\n",
+				s.File, s.Line, s.Name, r.Arch, r.Size, r.Align, r.PointerBytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}